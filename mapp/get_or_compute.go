@@ -0,0 +1,97 @@
+package mapp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sovietaced/go-redisson/mutex"
+)
+
+const defaultNegativeTTL = time.Minute
+
+// GetOrComputeOptions configures GetOrCompute.
+type GetOrComputeOptions struct {
+	cacheNegative bool
+	negativeTTL   time.Duration
+}
+
+func defaultGetOrComputeOptions() *GetOrComputeOptions {
+	opts := &GetOrComputeOptions{}
+	WithNegativeTTL(defaultNegativeTTL)(opts)
+	return opts
+}
+
+// GetOrComputeOption configures GetOrCompute.
+type GetOrComputeOption func(*GetOrComputeOptions)
+
+// WithCacheNegative caches the zero value of V when loader returns an error, so repeated misses for the same key
+// don't repeatedly invoke loader until negativeTTL elapses.
+func WithCacheNegative(cacheNegative bool) GetOrComputeOption {
+	return func(o *GetOrComputeOptions) {
+		o.cacheNegative = cacheNegative
+	}
+}
+
+// WithNegativeTTL specifies the TTL applied to a cached negative result. Only meaningful alongside WithCacheNegative.
+func WithNegativeTTL(ttl time.Duration) GetOrComputeOption {
+	return func(o *GetOrComputeOptions) {
+		o.negativeTTL = ttl
+	}
+}
+
+// GetOrCompute returns the cached value for key, computing and caching it via loader on a miss. Concurrent callers
+// racing on the same key are serialized behind a mutex.Mutex on a derived lock key so that loader only runs once;
+// once the first caller releases the lock, waiters re-check the cache and pick up the value it populated.
+func (c *Mapp[K, V]) GetOrCompute(ctx context.Context, key K, loader func(ctx context.Context) (V, error), ttl time.Duration, options ...GetOrComputeOption) (V, error) {
+	value, exists, err := c.Get(ctx, key)
+	if err != nil {
+		return *new(V), fmt.Errorf("getting value: %w", err)
+	}
+	if exists {
+		return value, nil
+	}
+
+	opts := defaultGetOrComputeOptions()
+	for _, option := range options {
+		option(opts)
+	}
+
+	keyString, err := c.computeKey(ctx, key)
+	if err != nil {
+		return *new(V), fmt.Errorf("computing key: %w", err)
+	}
+
+	lock := mutex.NewMutex(c.client, fmt.Sprintf("%s:lock", keyString))
+	if err := lock.Lock(ctx); err != nil {
+		return *new(V), fmt.Errorf("acquiring compute lock: %w", err)
+	}
+	defer func() {
+		_ = lock.Unlock(ctx)
+	}()
+
+	// Re-check now that we hold the lock; another waiter may have already computed the value.
+	value, exists, err = c.Get(ctx, key)
+	if err != nil {
+		return *new(V), fmt.Errorf("getting value: %w", err)
+	}
+	if exists {
+		return value, nil
+	}
+
+	loaded, err := loader(ctx)
+	if err != nil {
+		if opts.cacheNegative {
+			if setErr := c.SetWithTTL(ctx, key, *new(V), opts.negativeTTL); setErr != nil {
+				return *new(V), fmt.Errorf("caching negative result: %w", setErr)
+			}
+		}
+		return *new(V), fmt.Errorf("loading value: %w", err)
+	}
+
+	if err := c.SetWithTTL(ctx, key, loaded, ttl); err != nil {
+		return *new(V), fmt.Errorf("caching loaded value: %w", err)
+	}
+
+	return loaded, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/redis/go-redis/v9"
 	"github.com/sovietaced/go-redisson/marshal"
+	"strings"
+	"time"
 )
 
 type Options[K any, V any] struct {
@@ -80,6 +82,11 @@ func (c *Mapp[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
 }
 
 func (c *Mapp[K, V]) Set(ctx context.Context, key K, value V) error {
+	return c.SetWithTTL(ctx, key, value, 0)
+}
+
+// SetWithTTL inserts a key/value into the map with an expiration. A ttl of 0 means the key never expires.
+func (c *Mapp[K, V]) SetWithTTL(ctx context.Context, key K, value V, ttl time.Duration) error {
 	keyString, err := c.computeKey(ctx, key)
 	if err != nil {
 		return fmt.Errorf("computing key: %w", err)
@@ -90,9 +97,9 @@ func (c *Mapp[K, V]) Set(ctx context.Context, key K, value V) error {
 		return fmt.Errorf("marshalling value: %w", err)
 	}
 
-	result := c.client.Set(ctx, keyString, marshaledValue, 0)
+	result := c.client.Set(ctx, keyString, marshaledValue, ttl)
 	if result.Err() != nil {
-		return fmt.Errorf("setting key=%s: %w", keyString, err)
+		return fmt.Errorf("setting key=%s: %w", keyString, result.Err())
 	}
 
 	return nil
@@ -106,12 +113,152 @@ func (c *Mapp[K, V]) Del(ctx context.Context, key K) error {
 
 	result := c.client.Del(ctx, keyString)
 	if result.Err() != nil {
-		return fmt.Errorf("deleting key=%s: %w", keyString, err)
+		return fmt.Errorf("deleting key=%s: %w", keyString, result.Err())
+	}
+
+	return nil
+}
+
+// MGet retrieves several keys at once, pipelining the underlying Redis commands. The returned map is keyed by the
+// marshaled (and namespaced) key string, since K is only constrained to any and may not be comparable/usable as a
+// map key itself. Only contains entries for keys that exist.
+func (c *Mapp[K, V]) MGet(ctx context.Context, keys []K) (map[string]V, error) {
+	values := make(map[string]V, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	keyStrings := make([]string, len(keys))
+	for i, key := range keys {
+		keyString, err := c.computeKey(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("computing key: %w", err)
+		}
+		keyStrings[i] = keyString
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keyStrings))
+	for i, keyString := range keyStrings {
+		cmds[i] = pipe.Get(ctx, keyString)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("getting values: %w", err)
+	}
+
+	for i, cmd := range cmds {
+		if cmd.Err() == redis.Nil {
+			continue
+		}
+		if cmd.Err() != nil {
+			return nil, fmt.Errorf("getting value: %w", cmd.Err())
+		}
+
+		value := new(V)
+		if err := c.valueMarshaler.Unmarshal(ctx, cmd.Val(), value); err != nil {
+			return nil, fmt.Errorf("unmarshalling value: %w", err)
+		}
+		values[keyStrings[i]] = *value
+	}
+
+	return values, nil
+}
+
+// MSet inserts several key/values at once, pipelining the underlying Redis commands. entries is keyed by the
+// marshaled (and namespaced) key string, matching MGet's return type.
+func (c *Mapp[K, V]) MSet(ctx context.Context, entries map[string]V) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for keyString, value := range entries {
+		marshaledValue, err := c.valueMarshaler.Marshal(ctx, value)
+		if err != nil {
+			return fmt.Errorf("marshalling value: %w", err)
+		}
+
+		pipe.Set(ctx, keyString, marshaledValue, 0)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("setting values: %w", err)
 	}
 
 	return nil
 }
 
+// MDel removes several keys at once.
+func (c *Mapp[K, V]) MDel(ctx context.Context, keys []K) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	keyStrings := make([]string, len(keys))
+	for i, key := range keys {
+		keyString, err := c.computeKey(ctx, key)
+		if err != nil {
+			return fmt.Errorf("computing key: %w", err)
+		}
+		keyStrings[i] = keyString
+	}
+
+	if err := c.client.Del(ctx, keyStrings...).Err(); err != nil {
+		return fmt.Errorf("deleting keys: %w", err)
+	}
+
+	return nil
+}
+
+// Iterate walks every key/value in the map using SCAN cursor pagination, so large maps can be walked without KEYS.
+// fn is invoked for each entry; iteration stops early if fn returns false. Requires WithNamespace, since without one
+// a Mapp's keys are indistinguishable from the rest of the keyspace and Iterate would otherwise walk every key on
+// the server.
+func (c *Mapp[K, V]) Iterate(ctx context.Context, fn func(K, V) bool) error {
+	if len(c.namespace) == 0 {
+		return fmt.Errorf("iterate requires a namespace; configure one with WithNamespace")
+	}
+	match := fmt.Sprintf("%s:*", c.namespace)
+
+	var cursor uint64
+	for {
+		keyStrings, nextCursor, err := c.client.Scan(ctx, cursor, match, 100).Result()
+		if err != nil {
+			return fmt.Errorf("scanning keys: %w", err)
+		}
+
+		for _, keyString := range keyStrings {
+			result := c.client.Get(ctx, keyString)
+			if result.Err() != nil {
+				if result.Err() == redis.Nil {
+					continue
+				}
+				return fmt.Errorf("getting value: %w", result.Err())
+			}
+
+			key, err := c.parseKey(ctx, keyString)
+			if err != nil {
+				return fmt.Errorf("parsing key: %w", err)
+			}
+
+			value := new(V)
+			if err := c.valueMarshaler.Unmarshal(ctx, result.Val(), value); err != nil {
+				return fmt.Errorf("unmarshalling value: %w", err)
+			}
+
+			if !fn(key, *value) {
+				return nil
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
 func (c *Mapp[K, V]) computeKey(ctx context.Context, key K) (string, error) {
 	marshaledKey, err := c.keyMarshaler.Marshal(ctx, key)
 	if err != nil {
@@ -124,3 +271,18 @@ func (c *Mapp[K, V]) computeKey(ctx context.Context, key K) (string, error) {
 
 	return marshaledKey, nil
 }
+
+// parseKey recovers the original K from a namespaced Redis key, the inverse of computeKey.
+func (c *Mapp[K, V]) parseKey(ctx context.Context, keyString string) (K, error) {
+	marshaledKey := keyString
+	if len(c.namespace) > 0 {
+		marshaledKey = strings.TrimPrefix(keyString, c.namespace+":")
+	}
+
+	key := new(K)
+	if err := c.keyMarshaler.Unmarshal(ctx, marshaledKey, key); err != nil {
+		return *key, err
+	}
+
+	return *key, nil
+}
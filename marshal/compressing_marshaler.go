@@ -0,0 +1,119 @@
+package marshal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionAlgorithm identifies which compression algorithm was used to encode a value. It is stored alongside
+// each value so that Unmarshal can transparently decompress entries written under a different algorithm than the
+// CompressingMarshaler is currently configured with.
+type CompressionAlgorithm byte
+
+const (
+	CompressionGzip CompressionAlgorithm = iota + 1
+	CompressionZstd
+)
+
+// compressingMarshalerVersion lets the header format itself evolve without breaking already-stored values.
+const compressingMarshalerVersion byte = 1
+
+// CompressingMarshaler wraps an inner Marshaler[T] and compresses its output. This matters because values can be
+// large (image blobs, cached API responses) and JSON/MessagePack encoding alone is both slow and bulky. Encoded
+// values are prefixed with a two byte header (version, algorithm) so Unmarshal can handle values written by an
+// older configuration of this marshaler.
+type CompressingMarshaler[T any] struct {
+	inner     Marshaler[T]
+	algorithm CompressionAlgorithm
+}
+
+// NewCompressingMarshaler creates a CompressingMarshaler that compresses inner's output using algorithm.
+func NewCompressingMarshaler[T any](inner Marshaler[T], algorithm CompressionAlgorithm) *CompressingMarshaler[T] {
+	return &CompressingMarshaler[T]{inner: inner, algorithm: algorithm}
+}
+
+// Marshal marshals value via the inner Marshaler and compresses the result.
+func (cm *CompressingMarshaler[T]) Marshal(ctx context.Context, value T) (string, error) {
+	marshaled, err := cm.inner.Marshal(ctx, value)
+	if err != nil {
+		return "", fmt.Errorf("marshalling value: %w", err)
+	}
+
+	compressed, err := compress(cm.algorithm, []byte(marshaled))
+	if err != nil {
+		return "", fmt.Errorf("compressing value: %w", err)
+	}
+
+	header := []byte{compressingMarshalerVersion, byte(cm.algorithm)}
+	return string(append(header, compressed...)), nil
+}
+
+// Unmarshal decompresses valueString according to its header and unmarshals the result via the inner Marshaler.
+func (cm *CompressingMarshaler[T]) Unmarshal(ctx context.Context, valueString string, value *T) error {
+	if len(valueString) < 2 {
+		return fmt.Errorf("compressed value is missing its header")
+	}
+
+	algorithm := CompressionAlgorithm(valueString[1])
+
+	decompressed, err := decompress(algorithm, []byte(valueString[2:]))
+	if err != nil {
+		return fmt.Errorf("decompressing value: %w", err)
+	}
+
+	if err := cm.inner.Unmarshal(ctx, string(decompressed), value); err != nil {
+		return fmt.Errorf("unmarshalling value: %w", err)
+	}
+
+	return nil
+}
+
+func compress(algorithm CompressionAlgorithm, data []byte) ([]byte, error) {
+	switch algorithm {
+	case CompressionGzip:
+		var buf bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buf)
+		if _, err := gzipWriter.Write(data); err != nil {
+			return nil, err
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer encoder.Close()
+		return encoder.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm: %d", algorithm)
+	}
+}
+
+func decompress(algorithm CompressionAlgorithm, data []byte) ([]byte, error) {
+	switch algorithm {
+	case CompressionGzip:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		return io.ReadAll(gzipReader)
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		return decoder.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm: %d", algorithm)
+	}
+}
@@ -0,0 +1,38 @@
+package marshal
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompressingMarshaler(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("gzip round trip", func(t *testing.T) {
+		marshaler := NewCompressingMarshaler[string](&JsonMarshaler[string]{}, CompressionGzip)
+
+		marshalled, err := marshaler.Marshal(ctx, "test")
+		require.NoError(t, err)
+
+		var unmarshalled string
+		err = marshaler.Unmarshal(ctx, marshalled, &unmarshalled)
+		require.NoError(t, err)
+
+		require.Equal(t, "test", unmarshalled)
+	})
+
+	t.Run("zstd round trip", func(t *testing.T) {
+		marshaler := NewCompressingMarshaler[string](&JsonMarshaler[string]{}, CompressionZstd)
+
+		marshalled, err := marshaler.Marshal(ctx, "test")
+		require.NoError(t, err)
+
+		var unmarshalled string
+		err = marshaler.Unmarshal(ctx, marshalled, &unmarshalled)
+		require.NoError(t, err)
+
+		require.Equal(t, "test", unmarshalled)
+	})
+}
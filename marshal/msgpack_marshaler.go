@@ -0,0 +1,27 @@
+package marshal
+
+import (
+	"context"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MsgpackMarshaler is an implementation of Marshaler that uses MessagePack binary encoding, which is both faster
+// and more compact than JsonMarshaler for large or frequently (un)marshaled values.
+type MsgpackMarshaler[T any] struct {
+}
+
+// Marshal marshals a go struct into a MessagePack-encoded string.
+func (mm *MsgpackMarshaler[T]) Marshal(ctx context.Context, value T) (string, error) {
+	bytes, err := msgpack.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// Unmarshal unmarshals a go struct from a MessagePack-encoded string.
+func (mm *MsgpackMarshaler[T]) Unmarshal(ctx context.Context, valueString string, value *T) error {
+	return msgpack.Unmarshal([]byte(valueString), value)
+}
@@ -0,0 +1,34 @@
+package marshal
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoMessage constrains a value type T whose pointer type implements proto.Message, which is the shape generated
+// protobuf structs take. It lets ProtoMarshaler construct and unmarshal into a *T without the caller needing to
+// supply the pointer type separately.
+type ProtoMessage[T any] interface {
+	*T
+	proto.Message
+}
+
+// ProtoMarshaler is an implementation of Marshaler that uses protobuf binary encoding.
+type ProtoMarshaler[T any, PT ProtoMessage[T]] struct {
+}
+
+// Marshal marshals a proto message into its binary wire encoding.
+func (pm *ProtoMarshaler[T, PT]) Marshal(ctx context.Context, value T) (string, error) {
+	bytes, err := proto.Marshal(PT(&value))
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// Unmarshal unmarshals a proto message from its binary wire encoding.
+func (pm *ProtoMarshaler[T, PT]) Unmarshal(ctx context.Context, valueString string, value *T) error {
+	return proto.Unmarshal([]byte(valueString), PT(value))
+}
@@ -0,0 +1,19 @@
+package marshal
+
+import "context"
+
+// RawStringMarshaler is a Marshaler[string] that stores the string as-is. It avoids the quoting overhead that
+// JsonMarshaler[string] incurs for the common case where V is already a string.
+type RawStringMarshaler struct {
+}
+
+// Marshal returns value unchanged.
+func (rm *RawStringMarshaler) Marshal(ctx context.Context, value string) (string, error) {
+	return value, nil
+}
+
+// Unmarshal returns valueString unchanged.
+func (rm *RawStringMarshaler) Unmarshal(ctx context.Context, valueString string, value *string) error {
+	*value = valueString
+	return nil
+}
@@ -9,10 +9,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"log"
+	"strings"
+	"sync"
 	"time"
 )
 
 const defaultLeaseDuration = 30 * time.Second
+const defaultPollInterval = 100 * time.Millisecond
 const lockScript = `
 	if redis.call('exists', KEYS[1]) == 0 then
 		redis.call('set', KEYS[1], ARGV[2])
@@ -37,18 +40,46 @@ const extendScript = `
 `
 const unlockMsg = "unlocked"
 
+// WaitStrategy controls how Lock waits for a contended lock to become available.
+type WaitStrategy int
+
+const (
+	// PubSubWaitStrategy waits on the custom "unlocked" message published by Unlock. It is simple and requires no
+	// server configuration, but it cannot observe locks that are abandoned and expire via TTL rather than being
+	// explicitly unlocked, and it only interoperates with other go-redisson clients.
+	PubSubWaitStrategy WaitStrategy = iota
+	// KeyspaceNotificationWaitStrategy waits on Redis keyspace notifications for the lock key being deleted or
+	// expiring. This observes locks abandoned via TTL expiry and interoperates with non-go-redisson clients touching
+	// the same keys. It requires the server to be configured with "notify-keyspace-events" including the generic
+	// command and expired event classes, e.g. `CONFIG SET notify-keyspace-events Egx`. If that configuration is not
+	// detected, Lock falls back to polling at defaultPollInterval.
+	KeyspaceNotificationWaitStrategy
+)
+
 type Mutex struct {
-	clock         clock.Clock
-	client        redis.UniversalClient
-	key           string
-	leaseDuration time.Duration
-	logger        logr.Logger
+	clock           clock.Clock
+	client          redis.UniversalClient
+	key             string
+	leaseDuration   time.Duration
+	logger          logr.Logger
+	waitStrategy    WaitStrategy
+	keyspaceDB      int
+	legacyKeyFormat bool
+	failoverAware   bool
+
+	stateMu     sync.Mutex
+	masterRunID string
+	lastErr     error
 }
 
 type Options struct {
-	clock         clock.Clock
-	leaseDuration time.Duration
-	logger        logr.Logger
+	clock           clock.Clock
+	leaseDuration   time.Duration
+	logger          logr.Logger
+	waitStrategy    WaitStrategy
+	keyspaceDB      int
+	legacyKeyFormat bool
+	failoverAware   bool
 }
 
 func defaultOptions() *Options {
@@ -56,6 +87,10 @@ func defaultOptions() *Options {
 	WithClock(clock.New())(opts)
 	WithLeaseDuration(defaultLeaseDuration)(opts)
 	WithLogger(stdr.New(log.Default()))
+	WithWaitStrategy(PubSubWaitStrategy)(opts)
+	WithKeyspaceNotificationDB(0)(opts)
+	WithLegacyKeyFormat(false)(opts)
+	WithSentinelFailoverAware(false)(opts)
 	return opts
 }
 
@@ -83,17 +118,71 @@ func WithLogger(logger logr.Logger) Option {
 	}
 }
 
+// WithWaitStrategy selects how Lock waits for a contended lock to free up. Defaults to PubSubWaitStrategy.
+func WithWaitStrategy(waitStrategy WaitStrategy) Option {
+	return func(mo *Options) {
+		mo.waitStrategy = waitStrategy
+	}
+}
+
+// WithKeyspaceNotificationDB specifies which Redis logical database index to subscribe against when using
+// KeyspaceNotificationWaitStrategy. Defaults to 0 and is ignored by other wait strategies.
+func WithKeyspaceNotificationDB(db int) Option {
+	return func(mo *Options) {
+		mo.keyspaceDB = db
+	}
+}
+
+// WithLegacyKeyFormat uses the pre-hash-tag key naming scheme, where the lock and its pub sub channel don't
+// necessarily hash to the same Redis Cluster slot. Only set this for existing deployments that already have locks
+// stored under the legacy names; new deployments should leave this at its default of false so they work correctly
+// against Redis Cluster.
+func WithLegacyKeyFormat(legacy bool) Option {
+	return func(mo *Options) {
+		mo.legacyKeyFormat = legacy
+	}
+}
+
+// WithSentinelFailoverAware detects whether a Sentinel failover occurred while a lock was held, for clients that
+// connect through Sentinel. The master's run ID is recorded at acquisition time; if it changes before the lease is
+// released, the new master may not have replicated the lock key, so the background lease extender stops extending
+// and records ErrFailoverLost, retrievable via Mutex.Err.
+func WithSentinelFailoverAware(failoverAware bool) Option {
+	return func(mo *Options) {
+		mo.failoverAware = failoverAware
+	}
+}
+
 // NewMutex Creates a new Mutex with the provided options.
 func NewMutex(client redis.UniversalClient, key string, options ...Option) *Mutex {
 	opts := defaultOptions()
 	for _, option := range options {
 		option(opts)
 	}
-	return &Mutex{key: key, client: client, leaseDuration: opts.leaseDuration, clock: opts.clock, logger: opts.logger}
+	return &Mutex{
+		key:             key,
+		client:          client,
+		leaseDuration:   opts.leaseDuration,
+		clock:           opts.clock,
+		logger:          opts.logger,
+		waitStrategy:    opts.waitStrategy,
+		keyspaceDB:      opts.keyspaceDB,
+		legacyKeyFormat: opts.legacyKeyFormat,
+		failoverAware:   opts.failoverAware,
+	}
+}
+
+// Err returns the last error encountered by the background lease extender, such as ErrFailoverLost. Callers holding
+// a long-lived lock should check this before performing protected work if WithSentinelFailoverAware is enabled.
+func (m *Mutex) Err() error {
+	m.stateMu.Lock()
+	defer m.stateMu.Unlock()
+	return m.lastErr
 }
 
 // Lock blocks until the lock can be acquired. The function intelligently waits for either the lease duration to expire
-// or a pub sub notification that the lock has been released before attempting to acquire the lock.
+// or a notification that the lock has been released before attempting to acquire the lock again. How it waits for
+// that notification is controlled by WithWaitStrategy.
 func (m *Mutex) Lock(ctx context.Context) error {
 	success, err := m.TryLock(ctx)
 	if err != nil {
@@ -105,7 +194,16 @@ func (m *Mutex) Lock(ctx context.Context) error {
 		return nil
 	}
 
-	// Subscribe to pubsub notifications
+	switch m.waitStrategy {
+	case KeyspaceNotificationWaitStrategy:
+		return m.lockWaitingOnKeyspaceNotifications(ctx)
+	default:
+		return m.lockWaitingOnPubSub(ctx)
+	}
+}
+
+// lockWaitingOnPubSub waits on the custom unlock pub sub channel between acquisition attempts.
+func (m *Mutex) lockWaitingOnPubSub(ctx context.Context) error {
 	pubSub := m.client.Subscribe(ctx, m.getChannelName())
 	pubSubCh := pubSub.Channel()
 	defer func() {
@@ -134,11 +232,93 @@ func (m *Mutex) Lock(ctx context.Context) error {
 				break wait
 			}
 		case <-ctx.Done():
-			return fmt.Errorf("cancelled while waiting for lock: %w", err)
+			return fmt.Errorf("cancelled while waiting for lock: %w", ctx.Err())
 		}
 	}
 }
 
+// lockWaitingOnKeyspaceNotifications waits on Redis keyspace notifications for the lock key being deleted or
+// expiring between acquisition attempts, falling back to polling if the server isn't configured to emit them.
+func (m *Mutex) lockWaitingOnKeyspaceNotifications(ctx context.Context) error {
+	if !m.keyspaceNotificationsConfigured(ctx) {
+		m.logger.Info("notify-keyspace-events not configured for del/expired events, falling back to polling", "key", m.key)
+		return m.lockWaitingByPolling(ctx)
+	}
+
+	pubSub := m.client.PSubscribe(ctx, fmt.Sprintf("__keyevent@%d__:*", m.keyspaceDB))
+	pubSubCh := pubSub.Channel()
+	defer func() {
+		if closeErr := pubSub.Close(); closeErr != nil {
+			m.logger.Error(closeErr, "failed to close pub sub", "key", m.key)
+		}
+	}()
+
+	for {
+		ttl, err := m.doTryLock(ctx)
+		if err != nil {
+			return fmt.Errorf("trying to acquire lock: %w", err)
+		}
+
+		if ttl == 0 {
+			return nil
+		}
+
+	wait:
+		select {
+		case <-m.clock.After(time.Duration(ttl) * time.Millisecond):
+			break wait
+		case msg := <-pubSubCh:
+			if msg.Payload == m.getLockName() && (strings.HasSuffix(msg.Channel, ":del") || strings.HasSuffix(msg.Channel, ":expired")) {
+				break wait
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("cancelled while waiting for lock: %w", ctx.Err())
+		}
+	}
+}
+
+// lockWaitingByPolling waits by periodically retrying acquisition, used when keyspace notifications aren't
+// available on the server.
+func (m *Mutex) lockWaitingByPolling(ctx context.Context) error {
+	ticker := m.clock.Ticker(defaultPollInterval)
+	defer ticker.Stop()
+
+	for {
+		ttl, err := m.doTryLock(ctx)
+		if err != nil {
+			return fmt.Errorf("trying to acquire lock: %w", err)
+		}
+
+		if ttl == 0 {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("cancelled while waiting for lock: %w", ctx.Err())
+		}
+	}
+}
+
+// keyspaceNotificationsConfigured checks whether the server has notify-keyspace-events configured to emit both
+// generic command events (so DEL is reported) and expired events.
+func (m *Mutex) keyspaceNotificationsConfigured(ctx context.Context) bool {
+	result, err := m.client.ConfigGet(ctx, "notify-keyspace-events").Result()
+	if err != nil {
+		m.logger.Error(err, "failed to check notify-keyspace-events config", "key", m.key)
+		return false
+	}
+
+	flags := result["notify-keyspace-events"]
+	// A is only an alias for the event classes (g$lshzxet...), not for K/E, so keyevent delivery requires an
+	// explicit E.
+	hasKeyEvents := strings.ContainsRune(flags, 'E')
+	hasGeneric := strings.ContainsAny(flags, "gA")
+	hasExpired := strings.ContainsAny(flags, "xA")
+	return hasKeyEvents && hasGeneric && hasExpired
+}
+
 // TryLock attempts to acquire the lock but does not block. Returns whether the lock was aquired.
 func (m *Mutex) TryLock(ctx context.Context) (bool, error) {
 	ttl, err := m.doTryLock(ctx)
@@ -159,6 +339,20 @@ func (m *Mutex) doTryLock(ctx context.Context) (int64, error) {
 
 	// If lock was acquired, kick off lease extender
 	if ttl == 0 {
+		m.stateMu.Lock()
+		m.lastErr = nil
+		m.stateMu.Unlock()
+
+		if m.failoverAware {
+			if runID, err := m.masterRunIDNow(ctx); err != nil {
+				m.logger.Error(err, "failed to record master run ID for failover detection", "key", m.key)
+			} else {
+				m.stateMu.Lock()
+				m.masterRunID = runID
+				m.stateMu.Unlock()
+			}
+		}
+
 		go m.launchLeaseExtender(extensionId)
 	}
 
@@ -188,6 +382,7 @@ func (m *Mutex) launchLeaseExtender(extensionId uuid.UUID) {
 			success, err := m.extendLease(ctx, extensionId)
 			if err != nil {
 				m.logger.Error(err, "failed to extend lease", "key", m.key)
+				m.setLastErr(err)
 				return
 			}
 
@@ -200,7 +395,13 @@ func (m *Mutex) launchLeaseExtender(extensionId uuid.UUID) {
 }
 
 func (m *Mutex) extendLease(ctx context.Context, acquireId uuid.UUID) (bool, error) {
-	result, err := m.client.Eval(ctx, extendScript, []string{m.key}, m.leaseDuration.Milliseconds(), acquireId).Int64()
+	if m.failoverAware {
+		if err := m.checkFailover(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	result, err := m.client.Eval(ctx, extendScript, []string{m.getLockName()}, m.leaseDuration.Milliseconds(), acquireId).Int64()
 
 	if err != nil {
 		return false, fmt.Errorf("extending lease: %w", err)
@@ -213,10 +414,27 @@ func (m *Mutex) extendLease(ctx context.Context, acquireId uuid.UUID) (bool, err
 	return false, nil
 }
 
+func (m *Mutex) setLastErr(err error) {
+	m.stateMu.Lock()
+	defer m.stateMu.Unlock()
+	m.lastErr = err
+}
+
+// getLockName returns the Redis key for the lock's value. Unless WithLegacyKeyFormat is set, the user-supplied key
+// portion is wrapped in hash tags so that it hashes to the same Redis Cluster slot as getChannelName, avoiding a
+// CROSSSLOT error from the Lua scripts that touch both keys.
 func (m *Mutex) getLockName() string {
-	return fmt.Sprintf("go_redisson_lock:%s", m.key)
+	if m.legacyKeyFormat {
+		return fmt.Sprintf("go_redisson_lock:%s", m.key)
+	}
+	return fmt.Sprintf("go_redisson_lock:{%s}", m.key)
 }
 
+// getChannelName returns the Redis pub sub channel for unlock notifications. See getLockName for the hash tag
+// rationale.
 func (m *Mutex) getChannelName() string {
-	return fmt.Sprintf("go_redisson_lock_channel:%s", m.key)
+	if m.legacyKeyFormat {
+		return fmt.Sprintf("go_redisson_lock_channel:%s", m.key)
+	}
+	return fmt.Sprintf("go_redisson_lock_channel:{%s}", m.key)
 }
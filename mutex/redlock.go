@@ -0,0 +1,305 @@
+package mutex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/stdr"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"log"
+)
+
+// redlockUnlockScript is a compare-and-delete: it only deletes the lock key if it still holds the value this
+// attempt set (ARGV[1], the acquisition's extensionId), so cleaning up a failed or expired acquisition never
+// removes a key a different client has since successfully acquired.
+const redlockUnlockScript = `
+	if redis.call('get', KEYS[1]) == ARGV[1] then
+		return redis.call('del', KEYS[1])
+	else
+		return 0
+	end`
+
+// defaultRetryDelay is how long Lock waits between acquisition attempts against the full set of instances.
+const defaultRetryDelay = 200 * time.Millisecond
+
+// redlockTimeoutDivisor determines the per-instance acquisition timeout as a fraction of the lease duration, as
+// recommended by the Redlock algorithm (e.g. leaseMs/100).
+const redlockTimeoutDivisor = 100
+
+// driftFactor and driftOffset parameterize the clock drift estimate used by drift, as recommended by the Redlock
+// algorithm: drift = leaseMs*driftFactor + driftOffset.
+const driftFactor = 0.01
+const driftOffset = 2 * time.Millisecond
+
+// Redlock implements the Redlock algorithm (https://redis.io/docs/manual/patterns/distributed-locks/) across a set
+// of independent Redis instances, so that a minority of failed/unreachable instances does not compromise the lock.
+type Redlock struct {
+	clock         clock.Clock
+	clients       []redis.UniversalClient
+	key           string
+	leaseDuration time.Duration
+	retryDelay    time.Duration
+	logger        logr.Logger
+
+	mu          sync.Mutex
+	extensionId uuid.UUID
+}
+
+type RedlockOptions struct {
+	clock         clock.Clock
+	leaseDuration time.Duration
+	retryDelay    time.Duration
+	logger        logr.Logger
+}
+
+func defaultRedlockOptions() *RedlockOptions {
+	opts := &RedlockOptions{}
+	WithRedlockClock(clock.New())(opts)
+	WithRedlockLeaseDuration(defaultLeaseDuration)(opts)
+	WithRetryDelay(defaultRetryDelay)(opts)
+	WithRedlockLogger(stdr.New(log.Default()))(opts)
+	return opts
+}
+
+type RedlockOption func(*RedlockOptions)
+
+// WithRedlockLeaseDuration specifies the TTL on the underlying Redis cache entries in every instance.
+func WithRedlockLeaseDuration(leaseDuration time.Duration) RedlockOption {
+	return func(ro *RedlockOptions) {
+		ro.leaseDuration = leaseDuration
+	}
+}
+
+// WithRetryDelay specifies how long Lock waits between acquisition attempts across the full set of instances. Some
+// jitter is advisable in production to avoid thundering herds of competing clients, but is left to the caller.
+func WithRetryDelay(retryDelay time.Duration) RedlockOption {
+	return func(ro *RedlockOptions) {
+		ro.retryDelay = retryDelay
+	}
+}
+
+// WithRedlockClock allows a pluggable clock primarily for unit testing.
+func WithRedlockClock(clock clock.Clock) RedlockOption {
+	return func(ro *RedlockOptions) {
+		ro.clock = clock
+	}
+}
+
+// WithRedlockLogger allows a pluggable logger implementation.
+func WithRedlockLogger(logger logr.Logger) RedlockOption {
+	return func(ro *RedlockOptions) {
+		ro.logger = logger
+	}
+}
+
+// NewRedlock creates a new Redlock that coordinates acquisition across the provided, independent Redis instances.
+// Instances are expected to not replicate data between each other; running the algorithm across replicas of the
+// same master defeats its guarantees.
+func NewRedlock(clients []redis.UniversalClient, key string, options ...RedlockOption) *Redlock {
+	opts := defaultRedlockOptions()
+	for _, option := range options {
+		option(opts)
+	}
+	return &Redlock{
+		clients:       clients,
+		key:           key,
+		leaseDuration: opts.leaseDuration,
+		retryDelay:    opts.retryDelay,
+		clock:         opts.clock,
+		logger:        opts.logger,
+	}
+}
+
+// Lock blocks until the lock can be acquired across a quorum of instances, waiting the delay set by WithRetryDelay
+// in between attempts.
+func (r *Redlock) Lock(ctx context.Context) error {
+	for {
+		success, err := r.TryLock(ctx)
+		if err != nil {
+			return fmt.Errorf("trying to acquire lock: %w", err)
+		}
+
+		if success {
+			return nil
+		}
+
+		select {
+		case <-r.clock.After(r.retryDelay):
+		case <-ctx.Done():
+			return fmt.Errorf("cancelled while waiting for lock: %w", ctx.Err())
+		}
+	}
+}
+
+// TryLock attempts to acquire the lock across a quorum of instances but does not block. Returns whether the lock
+// was acquired.
+func (r *Redlock) TryLock(ctx context.Context) (bool, error) {
+	extensionId := uuid.New()
+
+	acquired, elapsed, err := r.acquire(ctx, extensionId)
+	if err != nil {
+		return false, fmt.Errorf("trylock failed: %w", err)
+	}
+
+	drift := r.drift()
+	quorum := r.quorum()
+
+	// Lock is only valid if a quorum of instances acknowledged it within the lease window, accounting for drift.
+	if acquired >= quorum && elapsed < r.leaseDuration-drift {
+		r.setExtensionId(extensionId)
+		go r.launchLeaseExtender(extensionId)
+		return true, nil
+	}
+
+	// Failed to acquire a valid lock; clean up any partial acquisitions using this attempt's extensionId, so we
+	// only ever remove a key we ourselves set on that instance, never one a different client has since acquired.
+	if err := r.unlockAll(context.Background(), extensionId); err != nil {
+		r.logger.Error(err, "failed to clean up partial redlock acquisition", "key", r.key)
+	}
+
+	return false, nil
+}
+
+// Unlock releases the lock on every instance.
+func (r *Redlock) Unlock(ctx context.Context) error {
+	return r.unlockAll(ctx, r.currentExtensionId())
+}
+
+func (r *Redlock) setExtensionId(extensionId uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.extensionId = extensionId
+}
+
+func (r *Redlock) currentExtensionId() uuid.UUID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.extensionId
+}
+
+func (r *Redlock) acquire(ctx context.Context, extensionId uuid.UUID) (int, time.Duration, error) {
+	start := r.clock.Now()
+
+	type result struct {
+		acquired bool
+	}
+	results := make(chan result, len(r.clients))
+
+	timeout := r.leaseDuration / redlockTimeoutDivisor
+
+	for _, client := range r.clients {
+		client := client
+		go func() {
+			instanceCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			ttl, err := client.Eval(instanceCtx, lockScript, []string{r.getLockName()}, r.leaseDuration.Milliseconds(), extensionId).Int64()
+			if err != nil {
+				r.logger.Error(err, "failed to acquire lock on instance", "key", r.key)
+				results <- result{acquired: false}
+				return
+			}
+
+			results <- result{acquired: ttl == 0}
+		}()
+	}
+
+	acquired := 0
+	for i := 0; i < len(r.clients); i++ {
+		res := <-results
+		if res.acquired {
+			acquired++
+		}
+	}
+
+	return acquired, r.clock.Now().Sub(start), nil
+}
+
+// unlockAll runs the compare-and-delete redlockUnlockScript against every instance, keyed off extensionId, so that
+// cleaning up a partial or stale acquisition never deletes a key some other client now legitimately holds.
+func (r *Redlock) unlockAll(ctx context.Context, extensionId uuid.UUID) error {
+	var firstErr error
+	for _, client := range r.clients {
+		if _, err := client.Eval(ctx, redlockUnlockScript, []string{r.getLockName()}, extensionId).Int64(); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("unlocking instance: %w", err)
+			}
+		}
+	}
+	return firstErr
+}
+
+func (r *Redlock) launchLeaseExtender(extensionId uuid.UUID) {
+	// Use a fresh root context here
+	ctx := context.Background()
+
+	ticker := r.clock.Ticker(r.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			success, err := r.extendLease(ctx, extensionId)
+			if err != nil {
+				r.logger.Error(err, "failed to extend lease", "key", r.key)
+				return
+			}
+
+			// we no longer hold a quorum
+			if !success {
+				return
+			}
+		}
+	}
+}
+
+// extendLease runs the extend script against every instance and only reports success if a quorum still hold the
+// value, matching the majority requirement used for acquisition.
+func (r *Redlock) extendLease(ctx context.Context, extensionId uuid.UUID) (bool, error) {
+	type result struct {
+		extended bool
+	}
+	results := make(chan result, len(r.clients))
+
+	for _, client := range r.clients {
+		client := client
+		go func() {
+			res, err := client.Eval(ctx, extendScript, []string{r.getLockName()}, r.leaseDuration.Milliseconds(), extensionId).Int64()
+			if err != nil {
+				r.logger.Error(err, "failed to extend lease on instance", "key", r.key)
+				results <- result{extended: false}
+				return
+			}
+			results <- result{extended: res == 1}
+		}()
+	}
+
+	extended := 0
+	for i := 0; i < len(r.clients); i++ {
+		res := <-results
+		if res.extended {
+			extended++
+		}
+	}
+
+	return extended >= r.quorum(), nil
+}
+
+// drift is the estimated clock drift across instances, as prescribed by the Redlock algorithm.
+func (r *Redlock) drift() time.Duration {
+	return time.Duration(float64(r.leaseDuration)*driftFactor) + driftOffset
+}
+
+// quorum is the minimum number of instances that must agree for the lock to be considered valid.
+func (r *Redlock) quorum() int {
+	return len(r.clients)/2 + 1
+}
+
+func (r *Redlock) getLockName() string {
+	return fmt.Sprintf("go_redisson_lock:%s", r.key)
+}
@@ -0,0 +1,96 @@
+package mutex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestRedlock(t *testing.T) {
+
+	ctx := context.Background()
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:latest",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForLog("Ready to accept connections"),
+	}
+	redisContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create redis container: %v", err)
+	}
+	defer func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err.Error())
+		}
+	}()
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	if err != nil {
+		t.Fatalf("failed to get container endpoint: %v", err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: endpoint})
+
+	t.Run("Try to take lock when free", func(t *testing.T) {
+		redlock := NewRedlock([]redis.UniversalClient{client}, RandomLockName())
+		success, err := redlock.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, success)
+	})
+
+	t.Run("Try to free lock when taken", func(t *testing.T) {
+		redlock := NewRedlock([]redis.UniversalClient{client}, RandomLockName())
+		success, err := redlock.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, success)
+
+		err = redlock.Unlock(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed acquisition does not delete a key another client holds", func(t *testing.T) {
+		key := RandomLockName()
+		redlock := NewRedlock([]redis.UniversalClient{client}, key)
+
+		// Simulate an instance already holding the lock for a different owner, as could happen if our lease
+		// expired during a pause and another client acquired it in the meantime.
+		require.NoError(t, client.Set(ctx, redlock.getLockName(), "other-owner", time.Minute).Err())
+
+		success, err := redlock.TryLock(ctx)
+		require.NoError(t, err)
+		require.False(t, success)
+
+		// The partial-acquisition cleanup must not have deleted the other owner's key.
+		value, err := client.Get(ctx, redlock.getLockName()).Result()
+		require.NoError(t, err)
+		require.Equal(t, "other-owner", value)
+	})
+
+	t.Run("unlock does not delete a key another client has since acquired", func(t *testing.T) {
+		key := RandomLockName()
+		redlock := NewRedlock([]redis.UniversalClient{client}, key)
+
+		success, err := redlock.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, success)
+
+		// Simulate our lease expiring and a different client successfully acquiring the lock in the meantime.
+		require.NoError(t, client.Set(ctx, redlock.getLockName(), "other-owner", time.Minute).Err())
+
+		err = redlock.Unlock(ctx)
+		require.NoError(t, err)
+
+		value, err := client.Get(ctx, redlock.getLockName()).Result()
+		require.NoError(t, err)
+		require.Equal(t, "other-owner", value)
+	})
+
+}
@@ -0,0 +1,265 @@
+package mutex
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/stdr"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"log"
+)
+
+// reentrantLockScript acquires the lock for owner ARGV[2] if the hash doesn't exist yet, or increments the owner's
+// hold count if it is already held by the same owner. Returns the owner's resulting hold count on acquisition/
+// reentry (always >= 1), or the negated PTTL if held by someone else.
+const reentrantLockScript = `
+	if redis.call('exists', KEYS[1]) == 0 then
+		redis.call('hset', KEYS[1], ARGV[2], 1)
+		redis.call('pexpire', KEYS[1], ARGV[1])
+		return 1
+	end
+	if redis.call('hexists', KEYS[1], ARGV[2]) == 1 then
+		local count = redis.call('hincrby', KEYS[1], ARGV[2], 1)
+		redis.call('pexpire', KEYS[1], ARGV[1])
+		return count
+	end
+	return -redis.call('pttl', KEYS[1])`
+
+// reentrantUnlockScript decrements owner ARGV[1]'s hold count, deleting the hash and publishing the unlock
+// notification once it reaches zero. Returns the remaining count, or -1 if the caller didn't hold the lock.
+const reentrantUnlockScript = `
+	if redis.call('hexists', KEYS[1], ARGV[1]) == 0 then
+		return -1;
+	end;
+	local count = redis.call('hincrby', KEYS[1], ARGV[1], -1);
+	if count > 0 then
+		return count;
+	end;
+	redis.call('del', KEYS[1]);
+	redis.call('publish', KEYS[2], ARGV[2]);
+	return 0;
+`
+
+// reentrantExtendScript refreshes the hash TTL as long as owner ARGV[2] still holds the lock.
+const reentrantExtendScript = `
+	if redis.call('hexists', KEYS[1], ARGV[2]) == 1 then
+		redis.call('pexpire', KEYS[1], ARGV[1]);
+		return 1;
+	end;
+	return 0;
+`
+
+// ReentrantMutex is a distributed lock that, unlike Mutex, allows the same owner to acquire it multiple times
+// without blocking on itself, matching Redisson's Java RLock semantics. Each additional Lock/TryLock call must be
+// matched with a corresponding Unlock call; the underlying Redis entry is only released once the hold count
+// reaches zero.
+type ReentrantMutex struct {
+	clock         clock.Clock
+	client        redis.UniversalClient
+	key           string
+	leaseDuration time.Duration
+	logger        logr.Logger
+	instanceId    uuid.UUID
+	token         string
+}
+
+type ReentrantOptions struct {
+	clock         clock.Clock
+	leaseDuration time.Duration
+	logger        logr.Logger
+	token         string
+}
+
+func defaultReentrantOptions() *ReentrantOptions {
+	opts := &ReentrantOptions{}
+	WithReentrantClock(clock.New())(opts)
+	WithReentrantLeaseDuration(defaultLeaseDuration)(opts)
+	WithReentrantLogger(stdr.New(log.Default()))(opts)
+	return opts
+}
+
+type ReentrantOption func(*ReentrantOptions)
+
+// WithReentrantLeaseDuration specifies the TTL on the underlying Redis hash entry.
+func WithReentrantLeaseDuration(leaseDuration time.Duration) ReentrantOption {
+	return func(ro *ReentrantOptions) {
+		ro.leaseDuration = leaseDuration
+	}
+}
+
+// WithReentrantClock allows a pluggable clock primarily for unit testing.
+func WithReentrantClock(clock clock.Clock) ReentrantOption {
+	return func(ro *ReentrantOptions) {
+		ro.clock = clock
+	}
+}
+
+// WithReentrantLogger allows a pluggable logger implementation.
+func WithReentrantLogger(logger logr.Logger) ReentrantOption {
+	return func(ro *ReentrantOptions) {
+		ro.logger = logger
+	}
+}
+
+// WithOwnerToken overrides the default per-instance owner identity with a caller-supplied token. Supplying the same
+// token to multiple ReentrantMutex instances (e.g. across goroutines) lets them reenter each other's holds, which
+// is useful when the logical owner is broader than a single instance, such as a request ID.
+func WithOwnerToken(token string) ReentrantOption {
+	return func(ro *ReentrantOptions) {
+		ro.token = token
+	}
+}
+
+// NewReentrantMutex creates a new ReentrantMutex with the provided options.
+func NewReentrantMutex(client redis.UniversalClient, key string, options ...ReentrantOption) *ReentrantMutex {
+	opts := defaultReentrantOptions()
+	for _, option := range options {
+		option(opts)
+	}
+	return &ReentrantMutex{
+		key:           key,
+		client:        client,
+		leaseDuration: opts.leaseDuration,
+		clock:         opts.clock,
+		logger:        opts.logger,
+		instanceId:    uuid.New(),
+		token:         opts.token,
+	}
+}
+
+// Lock blocks until the lock can be acquired. Reentrant acquisitions by the same owner never block.
+func (m *ReentrantMutex) Lock(ctx context.Context) error {
+	success, err := m.TryLock(ctx)
+	if err != nil {
+		return fmt.Errorf("trying to acquire lock: %w", err)
+	}
+
+	if success {
+		return nil
+	}
+
+	pubSub := m.client.Subscribe(ctx, m.getChannelName())
+	pubSubCh := pubSub.Channel()
+	defer func() {
+		if closeErr := pubSub.Close(); closeErr != nil {
+			m.logger.Error(closeErr, "failed to close pub sub", "key", m.key)
+		}
+	}()
+
+	for {
+		ttl, err := m.doTryLock(ctx)
+		if err != nil {
+			return fmt.Errorf("trying to acquire lock: %w", err)
+		}
+
+		if ttl == 0 {
+			return nil
+		}
+
+	wait:
+		select {
+		case <-m.clock.After(time.Duration(ttl) * time.Millisecond):
+			break wait
+		case msg := <-pubSubCh:
+			if msg.Payload == unlockMsg {
+				break wait
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("cancelled while waiting for lock: %w", ctx.Err())
+		}
+	}
+}
+
+// TryLock attempts to acquire the lock but does not block. Returns whether the lock was acquired or reentered.
+func (m *ReentrantMutex) TryLock(ctx context.Context) (bool, error) {
+	ttl, err := m.doTryLock(ctx)
+	if err != nil {
+		return false, fmt.Errorf("trylock failed: %w", err)
+	}
+
+	return ttl == 0, nil
+}
+
+func (m *ReentrantMutex) doTryLock(ctx context.Context) (int64, error) {
+	owner := m.owner()
+	result, err := m.client.Eval(ctx, reentrantLockScript, []string{m.getLockName()}, m.leaseDuration.Milliseconds(), owner).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	if result > 0 {
+		// Only the first acquisition (hold count transitioning 0->1) needs to launch the extender; deeper
+		// reentries share the extender already running for this owner.
+		if result == 1 {
+			go m.launchLeaseExtender()
+		}
+		return 0, nil
+	}
+
+	return -result, nil
+}
+
+// Unlock releases one level of the hold on the lock. Once the hold count reaches zero, the Redis entry is deleted
+// and an unlock notification is published.
+func (m *ReentrantMutex) Unlock(ctx context.Context) error {
+	_, err := m.client.Eval(ctx, reentrantUnlockScript, []string{m.getLockName(), m.getChannelName()}, m.owner(), unlockMsg).Int64()
+	if err != nil {
+		return fmt.Errorf("unlock failed: %w", err)
+	}
+
+	return nil
+}
+
+func (m *ReentrantMutex) launchLeaseExtender() {
+	// Use a fresh root context here
+	ctx := context.Background()
+
+	ticker := m.clock.Ticker(m.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			success, err := m.extendLease(ctx)
+			if err != nil {
+				m.logger.Error(err, "failed to extend lease", "key", m.key)
+				return
+			}
+
+			// our hold count has reached zero
+			if !success {
+				return
+			}
+		}
+	}
+}
+
+func (m *ReentrantMutex) extendLease(ctx context.Context) (bool, error) {
+	result, err := m.client.Eval(ctx, reentrantExtendScript, []string{m.getLockName()}, m.leaseDuration.Milliseconds(), m.owner()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("extending lease: %w", err)
+	}
+
+	return result == 1, nil
+}
+
+// owner returns the identity used to track holds in the Redis hash: the caller-supplied token if one was provided,
+// otherwise this instance's stable UUID.
+func (m *ReentrantMutex) owner() string {
+	if m.token != "" {
+		return m.token
+	}
+	return m.instanceId.String()
+}
+
+func (m *ReentrantMutex) getLockName() string {
+	return fmt.Sprintf("go_redisson_reentrant_lock:%s", m.key)
+}
+
+func (m *ReentrantMutex) getChannelName() string {
+	return fmt.Sprintf("go_redisson_reentrant_lock_channel:%s", m.key)
+}
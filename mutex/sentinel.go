@@ -0,0 +1,57 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFailoverLost is returned (and recorded on the Mutex, retrievable via Err) when WithSentinelFailoverAware
+// detects that a Sentinel failover happened while the lease was held. The new master may not have replicated the
+// lock key, so extending the lease against it can't be trusted to mean we still hold it; callers should treat the
+// lock as lost and decide whether to panic or retry.
+var ErrFailoverLost = errors.New("go-redisson: sentinel failover occurred while lock was held")
+
+// masterRunIDNow returns the run ID of the server client is currently connected to, via INFO server. Across a
+// Sentinel failover, a client connected through Sentinel reconnects to the new master and this value changes, since
+// it identifies the Redis process, not the logical master name. Only called when WithSentinelFailoverAware is set,
+// so there's no need to detect whether client itself is Sentinel-backed here.
+func (m *Mutex) masterRunIDNow(ctx context.Context) (string, error) {
+	info, err := m.client.Info(ctx, "server").Result()
+	if err != nil {
+		return "", fmt.Errorf("getting server info: %w", err)
+	}
+
+	for _, line := range strings.Split(info, "\r\n") {
+		if runID, found := strings.CutPrefix(line, "run_id:"); found {
+			return runID, nil
+		}
+	}
+
+	return "", fmt.Errorf("run_id not present in INFO server output")
+}
+
+// checkFailover compares the current master run ID against the one recorded at acquisition time, returning
+// ErrFailoverLost if they differ. A no-op when no run ID was recorded at acquisition, i.e. WithSentinelFailoverAware
+// wasn't set.
+func (m *Mutex) checkFailover(ctx context.Context) error {
+	m.stateMu.Lock()
+	recorded := m.masterRunID
+	m.stateMu.Unlock()
+
+	if recorded == "" {
+		return nil
+	}
+
+	current, err := m.masterRunIDNow(ctx)
+	if err != nil {
+		return fmt.Errorf("checking for failover: %w", err)
+	}
+
+	if current != "" && current != recorded {
+		return ErrFailoverLost
+	}
+
+	return nil
+}
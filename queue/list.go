@@ -0,0 +1,169 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sovietaced/go-redisson/marshal"
+)
+
+// defaultBackoff is how long Subscribe waits after an error before trying again.
+const defaultBackoff = time.Second
+
+// defaultIdleThreshold is how long a Stream message may sit unacknowledged before NewStream's Subscribe will
+// reclaim it from whichever consumer was handling it and try again.
+const defaultIdleThreshold = 30 * time.Second
+
+// defaultBlockTimeout is how long NewStream blocks waiting for new messages before polling again.
+const defaultBlockTimeout = 5 * time.Second
+
+// Options is shared by List, ReliableList and Stream; not every field is meaningful to every queue type.
+type Options[T any] struct {
+	marshaler     marshal.Marshaler[T]
+	backoff       time.Duration
+	idleThreshold time.Duration
+	blockTimeout  time.Duration
+}
+
+func defaultOptions[T any]() *Options[T] {
+	opts := &Options[T]{}
+	WithMarshaler[T](&marshal.JsonMarshaler[T]{})(opts)
+	WithBackoff[T](defaultBackoff)(opts)
+	WithIdleThreshold[T](defaultIdleThreshold)(opts)
+	WithBlockTimeout[T](defaultBlockTimeout)(opts)
+	return opts
+}
+
+type Option[T any] func(*Options[T])
+
+// WithMarshaler allows you to configure how values are marshaled to and from strings. Defaults to JSON.
+func WithMarshaler[T any](marshaler marshal.Marshaler[T]) Option[T] {
+	return func(o *Options[T]) {
+		o.marshaler = marshaler
+	}
+}
+
+// WithBackoff specifies how long Subscribe waits after a failed read from Redis before resuming consumption. List's
+// Subscribe also waits this long after a handler error; ReliableList and Stream do not.
+func WithBackoff[T any](backoff time.Duration) Option[T] {
+	return func(o *Options[T]) {
+		o.backoff = backoff
+	}
+}
+
+// WithIdleThreshold specifies, for NewStream, how long a message may remain unacknowledged before Subscribe
+// reclaims it via XAUTOCLAIM and redelivers it.
+func WithIdleThreshold[T any](idleThreshold time.Duration) Option[T] {
+	return func(o *Options[T]) {
+		o.idleThreshold = idleThreshold
+	}
+}
+
+// WithBlockTimeout specifies, for NewStream, how long XREADGROUP blocks waiting for new messages before returning
+// so Subscribe can check for reclaimable messages again.
+func WithBlockTimeout[T any](blockTimeout time.Duration) Option[T] {
+	return func(o *Options[T]) {
+		o.blockTimeout = blockTimeout
+	}
+}
+
+// List is a simple FIFO queue backed by a Redis list.
+type List[T any] struct {
+	client    redis.UniversalClient
+	name      string
+	marshaler marshal.Marshaler[T]
+	backoff   time.Duration
+}
+
+// NewList creates a new List backed by the Redis list named name. Uses a JSON marshaler by default.
+func NewList[T any](client redis.UniversalClient, name string, options ...Option[T]) *List[T] {
+	opts := defaultOptions[T]()
+	for _, option := range options {
+		option(opts)
+	}
+
+	return &List[T]{client: client, name: name, marshaler: opts.marshaler, backoff: opts.backoff}
+}
+
+// Push appends a value to the tail of the queue.
+func (l *List[T]) Push(ctx context.Context, value T) error {
+	marshaled, err := l.marshaler.Marshal(ctx, value)
+	if err != nil {
+		return fmt.Errorf("marshalling value: %w", err)
+	}
+
+	if err := l.client.RPush(ctx, l.name, marshaled).Err(); err != nil {
+		return fmt.Errorf("pushing value: %w", err)
+	}
+
+	return nil
+}
+
+// Pop blocks until a value is available at the head of the queue, or ctx is cancelled.
+func (l *List[T]) Pop(ctx context.Context) (T, error) {
+	result, err := l.client.BLPop(ctx, 0, l.name).Result()
+	if err != nil {
+		return *new(T), fmt.Errorf("popping value: %w", err)
+	}
+
+	// result[0] is the list name, result[1] is the value.
+	value := new(T)
+	if err := l.marshaler.Unmarshal(ctx, result[1], value); err != nil {
+		return *value, fmt.Errorf("unmarshalling value: %w", err)
+	}
+
+	return *value, nil
+}
+
+// PopBatch pops up to n values from the head of the queue without blocking. Returns fewer than n values, or none,
+// if the queue doesn't have enough entries.
+func (l *List[T]) PopBatch(ctx context.Context, n int64) ([]T, error) {
+	results, err := l.client.LPopCount(ctx, l.name, int(n)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("popping batch: %w", err)
+	}
+
+	values := make([]T, 0, len(results))
+	for _, result := range results {
+		value := new(T)
+		if err := l.marshaler.Unmarshal(ctx, result, value); err != nil {
+			return nil, fmt.Errorf("unmarshalling value: %w", err)
+		}
+		values = append(values, *value)
+	}
+
+	return values, nil
+}
+
+// Subscribe runs a blocking read loop that invokes handler for every value popped from the queue. It backs off for
+// WithBackoff between attempts after a handler or pop error, and returns when ctx is cancelled.
+func (l *List[T]) Subscribe(ctx context.Context, handler func(T) error) error {
+	for {
+		value, err := l.Pop(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			select {
+			case <-time.After(l.backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		if err := handler(value); err != nil {
+			select {
+			case <-time.After(l.backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package queue
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestList(t *testing.T) {
+
+	ctx := context.Background()
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:latest",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForLog("Ready to accept connections"),
+	}
+	redisContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create redis container: %v", err)
+	}
+	defer func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err.Error())
+		}
+	}()
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	if err != nil {
+		t.Fatalf("failed to get container endpoint: %v", err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: endpoint})
+
+	t.Run("push and pop", func(t *testing.T) {
+		list := NewList[string](client, RandomQueueName())
+
+		err := list.Push(ctx, "hello")
+		require.NoError(t, err)
+
+		popCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		value, err := list.Pop(popCtx)
+		require.NoError(t, err)
+		require.Equal(t, "hello", value)
+	})
+
+	t.Run("pop batch", func(t *testing.T) {
+		list := NewList[string](client, RandomQueueName())
+
+		require.NoError(t, list.Push(ctx, "one"))
+		require.NoError(t, list.Push(ctx, "two"))
+
+		values, err := list.PopBatch(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"one", "two"}, values)
+	})
+
+	t.Run("pop blocks until a value is pushed", func(t *testing.T) {
+		list := NewList[string](client, RandomQueueName())
+
+		popped := make(chan string, 1)
+		go func() {
+			value, err := list.Pop(ctx)
+			require.NoError(t, err)
+			popped <- value
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		require.NoError(t, list.Push(ctx, "late"))
+
+		require.Eventually(t, func() bool {
+			select {
+			case value := <-popped:
+				return value == "late"
+			default:
+				return false
+			}
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+}
+
+func RandomQueueName() string {
+	letters := []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+
+	b := make([]rune, 20)
+	for i := range b {
+		b[i] = letters[rand.Intn(len(letters))]
+	}
+	return string(b)
+}
@@ -0,0 +1,142 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sovietaced/go-redisson/marshal"
+)
+
+// ReliableList is a FIFO queue backed by a Redis list that moves popped values into a per-consumer processing list
+// until they are explicitly Ack'd, so that a consumer that crashes mid-processing doesn't lose them. Call Recover
+// on startup to requeue any values left over from a previous, crashed instance of this consumer.
+type ReliableList[T any] struct {
+	client         redis.UniversalClient
+	name           string
+	processingName string
+	marshaler      marshal.Marshaler[T]
+	backoff        time.Duration
+}
+
+// NewReliableList creates a new ReliableList backed by the Redis list named name. consumerId identifies this
+// consumer's processing list and must be stable across restarts of the same logical consumer so that Recover can
+// find and requeue its abandoned in-flight values.
+func NewReliableList[T any](client redis.UniversalClient, name string, consumerId string, options ...Option[T]) *ReliableList[T] {
+	opts := defaultOptions[T]()
+	for _, option := range options {
+		option(opts)
+	}
+
+	return &ReliableList[T]{
+		client:         client,
+		name:           name,
+		processingName: fmt.Sprintf("%s:processing:%s", name, consumerId),
+		marshaler:      opts.marshaler,
+		backoff:        opts.backoff,
+	}
+}
+
+// Push appends a value to the tail of the queue.
+func (l *ReliableList[T]) Push(ctx context.Context, value T) error {
+	marshaled, err := l.marshaler.Marshal(ctx, value)
+	if err != nil {
+		return fmt.Errorf("marshalling value: %w", err)
+	}
+
+	if err := l.client.RPush(ctx, l.name, marshaled).Err(); err != nil {
+		return fmt.Errorf("pushing value: %w", err)
+	}
+
+	return nil
+}
+
+// Pop blocks until a value is available, atomically moving it into this consumer's processing list. The returned
+// token must be passed to Ack or Nack once the value has been handled.
+func (l *ReliableList[T]) Pop(ctx context.Context) (T, string, error) {
+	marshaled, err := l.client.BLMove(ctx, l.name, l.processingName, "LEFT", "RIGHT", 0).Result()
+	if err != nil {
+		return *new(T), "", fmt.Errorf("popping value: %w", err)
+	}
+
+	value := new(T)
+	if err := l.marshaler.Unmarshal(ctx, marshaled, value); err != nil {
+		return *value, marshaled, fmt.Errorf("unmarshalling value: %w", err)
+	}
+
+	return *value, marshaled, nil
+}
+
+// Ack removes a value, identified by the token returned from Pop, from this consumer's processing list, marking it
+// as successfully handled.
+func (l *ReliableList[T]) Ack(ctx context.Context, token string) error {
+	if err := l.client.LRem(ctx, l.processingName, 1, token).Err(); err != nil {
+		return fmt.Errorf("acking value: %w", err)
+	}
+
+	return nil
+}
+
+// Nack removes a value, identified by the token returned from Pop, from this consumer's processing list and
+// requeues it onto the main queue for another attempt.
+func (l *ReliableList[T]) Nack(ctx context.Context, token string) error {
+	if err := l.client.LRem(ctx, l.processingName, 1, token).Err(); err != nil {
+		return fmt.Errorf("nacking value: %w", err)
+	}
+
+	if err := l.client.RPush(ctx, l.name, token).Err(); err != nil {
+		return fmt.Errorf("requeuing nacked value: %w", err)
+	}
+
+	return nil
+}
+
+// Recover requeues any values left in this consumer's processing list onto the head of the main queue, in their
+// original order, so they are popped again before values that were never popped. Call this once on startup, before
+// consuming, to recover from a previous crash of the same consumerId. Returns the number of values requeued.
+func (l *ReliableList[T]) Recover(ctx context.Context) (int64, error) {
+	var recovered int64
+	for {
+		_, err := l.client.RPopLPush(ctx, l.processingName, l.name).Result()
+		if err != nil {
+			if err == redis.Nil {
+				return recovered, nil
+			}
+			return recovered, fmt.Errorf("recovering value: %w", err)
+		}
+		recovered++
+	}
+}
+
+// Subscribe runs a blocking read loop that invokes handler for every value popped from the queue, Ack'ing values
+// whose handler returns nil and Nack'ing those whose handler returns an error. It backs off for WithBackoff after a
+// pop error, and returns when ctx is cancelled.
+func (l *ReliableList[T]) Subscribe(ctx context.Context, handler func(T) error) error {
+	for {
+		value, token, err := l.Pop(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			select {
+			case <-time.After(l.backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		if err := handler(value); err != nil {
+			if nackErr := l.Nack(ctx, token); nackErr != nil {
+				return fmt.Errorf("nacking after handler error: %w", nackErr)
+			}
+			continue
+		}
+
+		if err := l.Ack(ctx, token); err != nil {
+			return fmt.Errorf("acking value: %w", err)
+		}
+	}
+}
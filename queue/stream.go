@@ -0,0 +1,186 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sovietaced/go-redisson/marshal"
+)
+
+// streamField is the single field name used to store the marshaled value on each stream entry.
+const streamField = "value"
+
+// Stream is a queue backed by a Redis Stream with a consumer group, giving at-least-once delivery with explicit
+// acknowledgement and reclaiming of messages abandoned by crashed consumers.
+type Stream[T any] struct {
+	client        redis.UniversalClient
+	name          string
+	group         string
+	consumer      string
+	marshaler     marshal.Marshaler[T]
+	backoff       time.Duration
+	idleThreshold time.Duration
+	blockTimeout  time.Duration
+}
+
+// NewStream creates a new Stream backed by the Redis stream named name, consuming as consumer within group. The
+// consumer group is created (along with the stream, if necessary) if it doesn't already exist.
+func NewStream[T any](ctx context.Context, client redis.UniversalClient, name string, group string, consumer string, options ...Option[T]) (*Stream[T], error) {
+	opts := defaultOptions[T]()
+	for _, option := range options {
+		option(opts)
+	}
+
+	s := &Stream[T]{
+		client:        client,
+		name:          name,
+		group:         group,
+		consumer:      consumer,
+		marshaler:     opts.marshaler,
+		backoff:       opts.backoff,
+		idleThreshold: opts.idleThreshold,
+		blockTimeout:  opts.blockTimeout,
+	}
+
+	if err := client.XGroupCreateMkStream(ctx, name, group, "$").Err(); err != nil && err.Error() != "BUSYGROUP Consumer Group name already exists" {
+		return nil, fmt.Errorf("creating consumer group: %w", err)
+	}
+
+	return s, nil
+}
+
+// Push appends a value to the stream.
+func (s *Stream[T]) Push(ctx context.Context, value T) error {
+	marshaled, err := s.marshaler.Marshal(ctx, value)
+	if err != nil {
+		return fmt.Errorf("marshalling value: %w", err)
+	}
+
+	if err := s.client.XAdd(ctx, &redis.XAddArgs{Stream: s.name, Values: map[string]any{streamField: marshaled}}).Err(); err != nil {
+		return fmt.Errorf("pushing value: %w", err)
+	}
+
+	return nil
+}
+
+// Ack acknowledges a message, identified by the id returned alongside it, as successfully handled.
+func (s *Stream[T]) Ack(ctx context.Context, id string) error {
+	if err := s.client.XAck(ctx, s.name, s.group, id).Err(); err != nil {
+		return fmt.Errorf("acking message: %w", err)
+	}
+
+	return nil
+}
+
+// Pending returns the number of messages delivered to the group but not yet acknowledged.
+func (s *Stream[T]) Pending(ctx context.Context) (int64, error) {
+	summary, err := s.client.XPending(ctx, s.name, s.group).Result()
+	if err != nil {
+		return 0, fmt.Errorf("getting pending messages: %w", err)
+	}
+
+	return summary.Count, nil
+}
+
+// reclaim autoclaims messages that have been idle for longer than idleThreshold, so a crashed consumer's in-flight
+// messages get redelivered to this one.
+func (s *Stream[T]) reclaim(ctx context.Context) ([]redis.XMessage, error) {
+	messages, _, err := s.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   s.name,
+		Group:    s.group,
+		Consumer: s.consumer,
+		MinIdle:  s.idleThreshold,
+		Start:    "0-0",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reclaiming messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// read blocks for up to blockTimeout waiting for new messages delivered to this consumer.
+func (s *Stream[T]) read(ctx context.Context) ([]redis.XMessage, error) {
+	streams, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    s.group,
+		Consumer: s.consumer,
+		Streams:  []string{s.name, ">"},
+		Count:    10,
+		Block:    s.blockTimeout,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading messages: %w", err)
+	}
+
+	if len(streams) == 0 {
+		return nil, nil
+	}
+
+	return streams[0].Messages, nil
+}
+
+func (s *Stream[T]) unmarshal(ctx context.Context, message redis.XMessage) (T, error) {
+	raw, ok := message.Values[streamField].(string)
+	if !ok {
+		return *new(T), fmt.Errorf("message %s missing %q field", message.ID, streamField)
+	}
+
+	value := new(T)
+	if err := s.marshaler.Unmarshal(ctx, raw, value); err != nil {
+		return *value, fmt.Errorf("unmarshalling value: %w", err)
+	}
+
+	return *value, nil
+}
+
+// Subscribe runs a blocking read loop that invokes handler for every message delivered to this consumer, including
+// messages reclaimed from consumers that went idle for longer than WithIdleThreshold. Messages are acknowledged
+// only once handler returns nil; unacknowledged messages remain pending and are retried after being reclaimed. It
+// backs off for WithBackoff after a read error, and returns when ctx is cancelled.
+func (s *Stream[T]) Subscribe(ctx context.Context, handler func(T) error) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		reclaimed, err := s.reclaim(ctx)
+		if err != nil {
+			select {
+			case <-time.After(s.backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		messages, err := s.read(ctx)
+		if err != nil {
+			select {
+			case <-time.After(s.backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		for _, message := range append(reclaimed, messages...) {
+			value, err := s.unmarshal(ctx, message)
+			if err != nil {
+				return fmt.Errorf("handling message %s: %w", message.ID, err)
+			}
+
+			if err := handler(value); err != nil {
+				continue
+			}
+
+			if err := s.Ack(ctx, message.ID); err != nil {
+				return fmt.Errorf("acking message %s: %w", message.ID, err)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/redis/go-redis/v9"
 	"github.com/sovietaced/go-redisson/marshal"
+	"time"
 )
 
 // Options for the Map
@@ -93,7 +94,25 @@ func (c *Map[K, V]) Set(ctx context.Context, key K, value V) error {
 
 	result := c.client.HSet(ctx, c.namespace, keyString, marshaledValue)
 	if result.Err() != nil {
-		return fmt.Errorf("setting key=%s: %w", keyString, err)
+		return fmt.Errorf("setting key=%s: %w", keyString, result.Err())
+	}
+
+	return nil
+}
+
+// SetWithTTL inserts a key/value into the map with a per-field expiration, using HPEXPIRE. Requires Redis 7.4+.
+func (c *Map[K, V]) SetWithTTL(ctx context.Context, key K, value V, ttl time.Duration) error {
+	if err := c.Set(ctx, key, value); err != nil {
+		return err
+	}
+
+	keyString, err := c.computeKey(ctx, key)
+	if err != nil {
+		return fmt.Errorf("computing key: %w", err)
+	}
+
+	if err := c.client.HPExpire(ctx, c.namespace, ttl, keyString).Err(); err != nil {
+		return fmt.Errorf("setting ttl for key=%s: %w", keyString, err)
 	}
 
 	return nil
@@ -109,12 +128,134 @@ func (c *Map[K, V]) Del(ctx context.Context, key K) error {
 
 	result := c.client.HDel(ctx, c.namespace, keyString)
 	if result.Err() != nil {
-		return fmt.Errorf("deleting key=%s: %w", keyString, err)
+		return fmt.Errorf("deleting key=%s: %w", keyString, result.Err())
+	}
+
+	return nil
+}
+
+// MGet retrieves several keys at once via HMGET. The returned map is keyed by the marshaled key string (K is only
+// constrained to any, so it may not be comparable/usable as a map key itself) and only contains entries for keys
+// that exist.
+func (c *Map[K, V]) MGet(ctx context.Context, keys []K) (map[string]V, error) {
+	values := make(map[string]V, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	keyStrings := make([]string, len(keys))
+	for i, key := range keys {
+		keyString, err := c.computeKey(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("computing key: %w", err)
+		}
+		keyStrings[i] = keyString
+	}
+
+	results, err := c.client.HMGet(ctx, c.namespace, keyStrings...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("getting values: %w", err)
+	}
+
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+
+		valueString, ok := result.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value type for key=%s", keyStrings[i])
+		}
+
+		value := new(V)
+		if err := c.valueMarshaler.Unmarshal(ctx, valueString, value); err != nil {
+			return nil, fmt.Errorf("unmarshalling value: %w", err)
+		}
+		values[keyStrings[i]] = *value
+	}
+
+	return values, nil
+}
+
+// MSet inserts several key/values at once via HMSET. entries is keyed by the marshaled key string, matching MGet's
+// return type.
+func (c *Map[K, V]) MSet(ctx context.Context, entries map[string]V) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	fields := make([]any, 0, len(entries)*2)
+	for keyString, value := range entries {
+		marshaledValue, err := c.valueMarshaler.Marshal(ctx, value)
+		if err != nil {
+			return fmt.Errorf("marshalling value: %w", err)
+		}
+
+		fields = append(fields, keyString, marshaledValue)
+	}
+
+	if err := c.client.HSet(ctx, c.namespace, fields...).Err(); err != nil {
+		return fmt.Errorf("setting values: %w", err)
+	}
+
+	return nil
+}
+
+// MDel removes several keys at once via HDEL.
+func (c *Map[K, V]) MDel(ctx context.Context, keys []K) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	keyStrings := make([]string, len(keys))
+	for i, key := range keys {
+		keyString, err := c.computeKey(ctx, key)
+		if err != nil {
+			return fmt.Errorf("computing key: %w", err)
+		}
+		keyStrings[i] = keyString
+	}
+
+	if err := c.client.HDel(ctx, c.namespace, keyStrings...).Err(); err != nil {
+		return fmt.Errorf("deleting keys: %w", err)
 	}
 
 	return nil
 }
 
+// Iterate walks every key/value in the map using HSCAN cursor pagination, so large maps can be walked without
+// loading the whole hash at once. fn is invoked for each entry; iteration stops early if fn returns false.
+func (c *Map[K, V]) Iterate(ctx context.Context, fn func(K, V) bool) error {
+	var cursor uint64
+	for {
+		fields, nextCursor, err := c.client.HScan(ctx, c.namespace, cursor, "*", 100).Result()
+		if err != nil {
+			return fmt.Errorf("scanning fields: %w", err)
+		}
+
+		for i := 0; i+1 < len(fields); i += 2 {
+			key := new(K)
+			if err := c.keyMarshaler.Unmarshal(ctx, fields[i], key); err != nil {
+				return fmt.Errorf("unmarshalling key: %w", err)
+			}
+
+			value := new(V)
+			if err := c.valueMarshaler.Unmarshal(ctx, fields[i+1], value); err != nil {
+				return fmt.Errorf("unmarshalling value: %w", err)
+			}
+
+			if !fn(*key, *value) {
+				return nil
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
 // computeKey computes the key to use for a key/value.
 func (c *Map[K, V]) computeKey(ctx context.Context, key K) (string, error) {
 	marshaledKey, err := c.keyMarshaler.Marshal(ctx, key)
@@ -8,6 +8,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 	"math/rand"
 	"testing"
+	"time"
 )
 
 func TestCache(t *testing.T) {
@@ -58,6 +59,76 @@ func TestCache(t *testing.T) {
 		require.Equal(t, "", value)
 	})
 
+	t.Run("set with ttl expires the field", func(t *testing.T) {
+		cache := NewMap[string, string](client, RandomNamespace())
+
+		err = cache.SetWithTTL(ctx, "key", "value", 100*time.Millisecond)
+		require.NoError(t, err)
+
+		value, exists, err := cache.Get(ctx, "key")
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, "value", value)
+
+		require.Eventually(t, func() bool {
+			_, exists, err := cache.Get(ctx, "key")
+			require.NoError(t, err)
+			return !exists
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("mget, mset, mdel multiple key/values", func(t *testing.T) {
+		cache := NewMap[string, string](client, RandomNamespace())
+
+		// MSet and MGet are keyed by the marshaled key string, which for the default JSON marshaler is quoted.
+		err = cache.MSet(ctx, map[string]string{`"one"`: "1", `"two"`: "2"})
+		require.NoError(t, err)
+
+		values, err := cache.MGet(ctx, []string{"one", "two", "missing"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{`"one"`: "1", `"two"`: "2"}, values)
+
+		err = cache.MDel(ctx, []string{"one", "two"})
+		require.NoError(t, err)
+
+		values, err = cache.MGet(ctx, []string{"one", "two"})
+		require.NoError(t, err)
+		require.Empty(t, values)
+	})
+
+	t.Run("iterate visits every key/value", func(t *testing.T) {
+		cache := NewMap[string, string](client, RandomNamespace())
+
+		require.NoError(t, cache.MSet(ctx, map[string]string{`"one"`: "1", `"two"`: "2", `"three"`: "3"}))
+
+		seen := map[string]string{}
+		err = cache.Iterate(ctx, func(key string, value string) bool {
+			seen[key] = value
+			return true
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"one": "1", "two": "2", "three": "3"}, seen)
+	})
+
+	t.Run("get or compute only invokes the loader once for repeated callers", func(t *testing.T) {
+		cache := NewMap[string, string](client, RandomNamespace())
+
+		var calls int
+		loader := func(ctx context.Context) (string, error) {
+			calls++
+			return "computed", nil
+		}
+
+		value, err := cache.GetOrCompute(ctx, "key", loader, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "computed", value)
+
+		value, err = cache.GetOrCompute(ctx, "key", loader, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "computed", value)
+		require.Equal(t, 1, calls)
+	})
+
 }
 
 func RandomNamespace() string {